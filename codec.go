@@ -0,0 +1,241 @@
+package sudokusolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GridFormat identifies one of the interchange formats supported by
+// ParseGrid and Grid.Encode.
+type GridFormat int
+
+const (
+	// FormatLine is the compact 81-character single-line form, using
+	// '.' (or '0') for empty cells.
+	FormatLine GridFormat = iota
+	// FormatBordered is the multi-line ASCII form delimited by
+	// "+---+---+---+" borders, e.g.:
+	//
+	// +---+---+---+
+	// |53.|.7.|...|
+	// |6..|195|...|
+	// |.98|...|.6.|
+	// +---+---+---+
+	// |8..|.6.|..3|
+	// |4..|8.3|..1|
+	// |7..|.2.|..6|
+	// +---+---+---+
+	// |.6.|...|28.|
+	// |...|419|..5|
+	// |...|.8.|.79|
+	// +---+---+---+
+	FormatBordered
+	// FormatJSON is a JSON array of 9 arrays of 9 ints, e.g.
+	// [[5,3,0,...],...].
+	FormatJSON
+)
+
+// ParseError reports a problem parsing a grid, identifying the
+// offending line and column where possible.
+type ParseError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("sudokusolver: parse error at line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// ParseGrid parses s as a Grid, auto-detecting which of FormatLine,
+// FormatBordered, or FormatJSON it is in. Both '.' and '0' are
+// accepted as empty-cell markers. On failure it returns a *ParseError
+// identifying the offending line/column.
+func ParseGrid(s string) (Grid, error) {
+	trimmed := strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		return parseJSONGrid(trimmed)
+	case strings.ContainsAny(trimmed, "+|"):
+		return parseBorderedGrid(trimmed)
+	default:
+		return parseLineGrid(trimmed)
+	}
+}
+
+func cellFromByte(b byte) (Cell, bool) {
+	switch {
+	case b == '.' || b == '0':
+		return 0, true
+	case b >= '1' && b <= '9':
+		return Cell(b - '0'), true
+	default:
+		return 0, false
+	}
+}
+
+func parseLineGrid(s string) (Grid, error) {
+	var significant []byte
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if b == '\n' || b == '\r' || b == ' ' || b == '\t' {
+			continue
+		}
+		significant = append(significant, b)
+	}
+
+	if len(significant) != 81 {
+		return Grid{}, &ParseError{Line: 1, Col: len(significant) + 1,
+			Msg: fmt.Sprintf("expected 81 significant characters, got %d", len(significant))}
+	}
+
+	var g Grid
+	for i, b := range significant {
+		d, ok := cellFromByte(b)
+		if !ok {
+			return Grid{}, &ParseError{Line: 1, Col: i + 1,
+				Msg: fmt.Sprintf("unexpected character %q", b)}
+		}
+		g[i/9][i%9] = d
+	}
+
+	return g, nil
+}
+
+func parseBorderedGrid(s string) (Grid, error) {
+	var g Grid
+	row := 0
+	for lineNum, line := range strings.Split(s, "\n") {
+		col := 0
+		filled := 0
+		for i := 0; i < len(line); i++ {
+			b := line[i]
+			if b == '+' || b == '-' || b == '|' || b == ' ' || b == '\r' || b == '\t' {
+				continue
+			}
+			if row >= 9 {
+				return Grid{}, &ParseError{Line: lineNum + 1, Col: i + 1, Msg: "too many rows"}
+			}
+			d, ok := cellFromByte(b)
+			if !ok {
+				return Grid{}, &ParseError{Line: lineNum + 1, Col: i + 1,
+					Msg: fmt.Sprintf("unexpected character %q", b)}
+			}
+			if col >= 9 {
+				return Grid{}, &ParseError{Line: lineNum + 1, Col: i + 1, Msg: "too many columns"}
+			}
+			g[row][col] = d
+			col++
+			filled++
+		}
+		if filled > 0 {
+			if filled != 9 {
+				return Grid{}, &ParseError{Line: lineNum + 1, Col: len(line) + 1,
+					Msg: fmt.Sprintf("expected 9 cells in row, got %d", filled)}
+			}
+			row++
+		}
+	}
+
+	if row != 9 {
+		return Grid{}, &ParseError{Line: 1, Col: 1, Msg: fmt.Sprintf("expected 9 rows, got %d", row)}
+	}
+
+	return g, nil
+}
+
+func parseJSONGrid(s string) (Grid, error) {
+	var rows [][]int
+	if err := json.Unmarshal([]byte(s), &rows); err != nil {
+		return Grid{}, &ParseError{Line: 1, Col: 1, Msg: err.Error()}
+	}
+
+	if len(rows) != 9 {
+		return Grid{}, &ParseError{Line: 1, Col: 1, Msg: fmt.Sprintf("expected 9 rows, got %d", len(rows))}
+	}
+
+	var g Grid
+	for r, row := range rows {
+		if len(row) != 9 {
+			return Grid{}, &ParseError{Line: r + 1, Col: 1, Msg: fmt.Sprintf("expected 9 columns, got %d", len(row))}
+		}
+		for c, v := range row {
+			if v < 0 || v > 9 {
+				return Grid{}, &ParseError{Line: r + 1, Col: c + 1, Msg: fmt.Sprintf("digit %d out of range", v)}
+			}
+			g[r][c] = Cell(v)
+		}
+	}
+
+	return g, nil
+}
+
+// Encode renders g in the requested GridFormat.
+func (g Grid) Encode(format GridFormat) string {
+	switch format {
+	case FormatBordered:
+		return g.encodeBordered()
+	case FormatJSON:
+		return g.encodeJSON()
+	default:
+		return g.encodeLine()
+	}
+}
+
+func (g Grid) encodeLine() string {
+	var w strings.Builder
+	for _, rowCells := range g {
+		for _, cell := range rowCells {
+			if cell == 0 {
+				w.WriteByte('.')
+			} else {
+				w.WriteByte('0' + byte(cell))
+			}
+		}
+	}
+	return w.String()
+}
+
+func (g Grid) encodeBordered() string {
+	const border = "+---+---+---+\n"
+
+	var w strings.Builder
+	for row, rowCells := range g {
+		if row%3 == 0 {
+			w.WriteString(border)
+		}
+		w.WriteByte('|')
+		for col, cell := range rowCells {
+			if cell == 0 {
+				w.WriteByte('.')
+			} else {
+				w.WriteByte('0' + byte(cell))
+			}
+			if col%3 == 2 {
+				w.WriteByte('|')
+			}
+		}
+		w.WriteByte('\n')
+	}
+	w.WriteString(border)
+
+	return w.String()
+}
+
+func (g Grid) encodeJSON() string {
+	rows := make([][9]int, 9)
+	for r, rowCells := range g {
+		for c, cell := range rowCells {
+			rows[r][c] = int(cell)
+		}
+	}
+
+	b, err := json.Marshal(rows)
+	if err != nil {
+		// rows is a fixed, simple structure; this cannot fail.
+		panic(err)
+	}
+	return string(b)
+}
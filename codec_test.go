@@ -0,0 +1,91 @@
+package sudokusolver_test
+
+import (
+	"strings"
+	"testing"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const partialLine = "53..7...." +
+	"6..195..." +
+	".98....6." +
+	"8...6...3" +
+	"4..8.3..1" +
+	"7...2...6" +
+	".6....28." +
+	"...419..5" +
+	"....8..79"
+
+func TestParseGrid_Line(t *testing.T) {
+	g, err := sudokusolver.ParseGrid(partialLine)
+
+	require.NoError(t, err)
+	assert.Equal(t, partial, g)
+}
+
+func TestParseGrid_Line_ZeroMarkers(t *testing.T) {
+	zeroLine := ""
+	for _, b := range []byte(partialLine) {
+		if b == '.' {
+			zeroLine += "0"
+		} else {
+			zeroLine += string(b)
+		}
+	}
+
+	g, err := sudokusolver.ParseGrid(zeroLine)
+
+	require.NoError(t, err)
+	assert.Equal(t, partial, g)
+}
+
+func TestParseGrid_Bordered(t *testing.T) {
+	g, err := sudokusolver.ParseGrid(partial.Encode(sudokusolver.FormatBordered))
+
+	require.NoError(t, err)
+	assert.Equal(t, partial, g)
+}
+
+func TestParseGrid_Bordered_CRLF(t *testing.T) {
+	crlf := strings.ReplaceAll(partial.Encode(sudokusolver.FormatBordered), "\n", "\r\n")
+
+	g, err := sudokusolver.ParseGrid(crlf)
+
+	require.NoError(t, err)
+	assert.Equal(t, partial, g)
+}
+
+func TestParseGrid_JSON(t *testing.T) {
+	g, err := sudokusolver.ParseGrid(partial.Encode(sudokusolver.FormatJSON))
+
+	require.NoError(t, err)
+	assert.Equal(t, partial, g)
+}
+
+func TestParseGrid_InvalidLength(t *testing.T) {
+	_, err := sudokusolver.ParseGrid("53..7")
+
+	require.Error(t, err)
+	var parseErr *sudokusolver.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+}
+
+func TestGrid_Encode_Line(t *testing.T) {
+	assert.Equal(t, partialLine, partial.Encode(sudokusolver.FormatLine))
+}
+
+func TestGrid_Encode_RoundTrip(t *testing.T) {
+	for _, format := range []sudokusolver.GridFormat{
+		sudokusolver.FormatLine,
+		sudokusolver.FormatBordered,
+		sudokusolver.FormatJSON,
+	} {
+		encoded := partial.Encode(format)
+		g, err := sudokusolver.ParseGrid(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, partial, g)
+	}
+}
@@ -0,0 +1,46 @@
+package sudokusolver_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLX_Solvable(t *testing.T) {
+	start := time.Now()
+	actualGrid, actualSolved := sudokusolver.DLX(partial)
+	end := time.Now()
+
+	assert.Equal(t, filled, actualGrid)
+	assert.True(t, actualSolved)
+	fmt.Print("TIME: ", end.Sub(start), "\n")
+}
+
+func TestDLX_Unsolvable(t *testing.T) {
+	_, actualSolved := sudokusolver.DLX(unsolvable)
+
+	assert.False(t, actualSolved)
+}
+
+func TestEnumerateSolutions_StopsEarly(t *testing.T) {
+	count := 0
+	sudokusolver.EnumerateSolutions(partial, func(g sudokusolver.Grid) bool {
+		count++
+		return false
+	})
+
+	assert.Equal(t, 1, count)
+}
+
+func TestEnumerateSolutions_CountsMultiple(t *testing.T) {
+	count := 0
+	sudokusolver.EnumerateSolutions(sudokusolver.Grid{}, func(g sudokusolver.Grid) bool {
+		count++
+		return count < 5
+	})
+
+	assert.Equal(t, 5, count)
+}
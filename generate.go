@@ -0,0 +1,239 @@
+package sudokusolver
+
+import "math/rand"
+
+// ---
+// --- Counting and uniqueness
+// ---
+
+// CountSolutions returns the number of distinct solutions to g, up to
+// limit (it stops searching once limit is reached, so it is cheap to
+// use with limit=2 to test for uniqueness).
+func CountSolutions(g Grid, limit int) int {
+	if !g.Valid() {
+		return 0
+	}
+	return countSolutions(g, limit)
+}
+
+func countSolutions(grid Grid, limit int) int {
+	row, col, ok := firstEmptyCell(grid)
+	if !ok {
+		return 1
+	}
+
+	count := 0
+	for i := Cell(1); i <= 9; i++ {
+		variant := grid
+		variant[row][col] = i
+		if !variant.Valid() {
+			continue
+		}
+
+		count += countSolutions(variant, limit-count)
+		if count >= limit {
+			return count
+		}
+	}
+
+	return count
+}
+
+func firstEmptyCell(grid Grid) (row, col int, ok bool) {
+	for r, rowCells := range grid {
+		for c, cell := range rowCells {
+			if cell == 0 {
+				return r, c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Unique reports whether g has exactly one solution.
+func (g Grid) Unique() bool {
+	return CountSolutions(g, 2) == 1
+}
+
+// ---
+// --- Difficulty grading
+// ---
+
+// Difficulty grades how hard a puzzle is to solve by hand, based on
+// the most advanced technique HintWithReason needed to use.
+type Difficulty int
+
+const (
+	Easy Difficulty = iota
+	Medium
+	Hard
+	Expert
+)
+
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case Expert:
+		return "expert"
+	default:
+		return "unknown"
+	}
+}
+
+// techniqueTier maps a Technique to the Difficulty it requires.
+func techniqueTier(t Technique) Difficulty {
+	switch t {
+	case NakedSingle, HiddenSingle:
+		return Easy
+	case NakedPair, NakedTriple:
+		return Medium
+	case PointingPair, BoxLineReduction:
+		return Hard
+	default:
+		return Expert
+	}
+}
+
+// GradeDifficulty solves g using only the human techniques behind
+// HintWithReason, and grades it by the hardest technique required. If
+// those techniques cannot finish the puzzle (a human would need to
+// guess), it is graded Expert.
+func GradeDifficulty(g Grid) Difficulty {
+	working := g
+	grade := Easy
+
+	for !isComplete(working) {
+		move, reason, ok := HintWithReason(working)
+		if !ok {
+			return Expert
+		}
+
+		if tier := techniqueTier(reason.Technique); tier > grade {
+			grade = tier
+		}
+		working = working.Apply(move)
+	}
+
+	return grade
+}
+
+// ---
+// --- Generation
+// ---
+
+// maxGenerateAttempts bounds how many full grids Generate will try
+// before settling for the closest difficulty it found, so that an
+// unlucky seed can't spin forever chasing an exact grade.
+const maxGenerateAttempts = 25
+
+// Generate produces a new, uniquely-solvable puzzle of the requested
+// difficulty, deterministically derived from seed.
+//
+// Each attempt fills an empty grid completely via randomized
+// backtracking, then repeatedly clears a randomly chosen cell,
+// keeping the clear only if the puzzle remains uniquely solvable and
+// does not grade harder than requested (per GradeDifficulty), until
+// either the requested difficulty is reached or no further clue can
+// safely be removed. If an attempt doesn't land on the requested
+// difficulty exactly, Generate tries again with a fresh grid, up to
+// maxGenerateAttempts times, and finally returns whichever attempt
+// graded closest.
+func Generate(seed int64, difficulty Difficulty) Grid {
+	rng := rand.New(rand.NewSource(seed))
+
+	var closest Grid
+	closestDist := -1
+
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		puzzle, grade := stripToDifficulty(fillRandom(rng), rng, difficulty)
+		if grade == difficulty {
+			return puzzle
+		}
+
+		if dist := difficultyDistance(grade, difficulty); closestDist == -1 || dist < closestDist {
+			closest, closestDist = puzzle, dist
+		}
+	}
+
+	return closest
+}
+
+// stripToDifficulty clears cells from a full grid in random order,
+// keeping each clear only if the puzzle remains uniquely solvable and
+// does not grade harder than difficulty, stopping as soon as the
+// puzzle grades exactly at difficulty or no further clue can safely
+// be removed. It returns the resulting puzzle and its actual grade.
+func stripToDifficulty(full Grid, rng *rand.Rand, difficulty Difficulty) (Grid, Difficulty) {
+	puzzle := full
+	grade := GradeDifficulty(puzzle)
+
+	for _, idx := range rng.Perm(81) {
+		if grade == difficulty {
+			break
+		}
+
+		row, col := idx/9, idx%9
+		if puzzle[row][col] == 0 {
+			continue
+		}
+
+		saved := puzzle[row][col]
+		puzzle[row][col] = 0
+
+		if !puzzle.Unique() {
+			puzzle[row][col] = saved
+			continue
+		}
+
+		newGrade := GradeDifficulty(puzzle)
+		if newGrade > difficulty {
+			puzzle[row][col] = saved
+			continue
+		}
+		grade = newGrade
+	}
+
+	return puzzle, grade
+}
+
+// difficultyDistance is how many grades apart a and b are.
+func difficultyDistance(a, b Difficulty) int {
+	d := int(a) - int(b)
+	if d < 0 {
+		d = -d
+	}
+	return d
+}
+
+// fillRandom produces a complete, valid grid via randomized
+// backtracking.
+func fillRandom(rng *rand.Rand) Grid {
+	var grid Grid
+	if !fillRandomRec(&grid, rng) {
+		panic("sudokusolver: failed to generate a full grid")
+	}
+	return grid
+}
+
+func fillRandomRec(grid *Grid, rng *rand.Rand) bool {
+	row, col, ok := firstEmptyCell(*grid)
+	if !ok {
+		return true
+	}
+
+	for _, i := range rng.Perm(9) {
+		d := Cell(i + 1)
+		grid[row][col] = d
+		if grid.Valid() && fillRandomRec(grid, rng) {
+			return true
+		}
+	}
+	grid[row][col] = 0
+
+	return false
+}
@@ -0,0 +1,45 @@
+package sudokusolver_test
+
+import (
+	"testing"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountSolutions(t *testing.T) {
+	assert.Equal(t, 1, sudokusolver.CountSolutions(partial, 2))
+	assert.Equal(t, 1, sudokusolver.CountSolutions(filled, 2))
+	assert.Equal(t, 0, sudokusolver.CountSolutions(unsolvable, 2))
+}
+
+func TestGrid_Unique(t *testing.T) {
+	assert.True(t, partial.Unique())
+	assert.True(t, filled.Unique())
+	assert.False(t, sudokusolver.Grid{}.Unique())
+}
+
+func TestGenerate(t *testing.T) {
+	grid := sudokusolver.Generate(42, sudokusolver.Medium)
+
+	assert.True(t, grid.Valid())
+	assert.True(t, grid.Unique())
+
+	_, solved := sudokusolver.Backtrack(grid)
+	assert.True(t, solved)
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	a := sudokusolver.Generate(7, sudokusolver.Easy)
+	b := sudokusolver.Generate(7, sudokusolver.Easy)
+
+	assert.Equal(t, a, b)
+}
+
+func TestGenerate_MatchesRequestedDifficulty(t *testing.T) {
+	for _, d := range []sudokusolver.Difficulty{sudokusolver.Medium, sudokusolver.Hard} {
+		grid := sudokusolver.Generate(42, d)
+
+		assert.Equal(t, d, sudokusolver.GradeDifficulty(grid))
+	}
+}
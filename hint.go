@@ -0,0 +1,532 @@
+package sudokusolver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---
+// --- Human techniques
+// ---
+
+// Technique identifies which human solving strategy produced a Reason.
+// Constants are declared in increasing order of difficulty.
+type Technique int
+
+const (
+	NakedSingle Technique = iota
+	HiddenSingle
+	NakedPair
+	NakedTriple
+	PointingPair
+	BoxLineReduction
+)
+
+func (t Technique) String() string {
+	switch t {
+	case NakedSingle:
+		return "naked single"
+	case HiddenSingle:
+		return "hidden single"
+	case NakedPair:
+		return "naked pair"
+	case NakedTriple:
+		return "naked triple"
+	case PointingPair:
+		return "pointing pair"
+	case BoxLineReduction:
+		return "box-line reduction"
+	default:
+		return "unknown technique"
+	}
+}
+
+// CellRef identifies a single cell by row and column.
+type CellRef struct {
+	Row int
+	Col int
+}
+
+// Reason describes which technique justified a Move (or set of
+// eliminations), and which cells were used as the witness for it, so
+// that a UI can explain the deduction to a human solver.
+type Reason struct {
+	Technique Technique
+	Witnesses []CellRef
+	Unit      string
+	Digit     Cell
+	Detail    string
+}
+
+// unitName describes a unit for use in a Reason's Detail, given one
+// of its member cells and which kind of unit it is.
+func unitName(kind string, index int) string {
+	return fmt.Sprintf("%s %d", kind, index+1)
+}
+
+// HintWithReason runs a chain of human solving techniques against g
+// and returns the first move it can justify, along with a Reason
+// explaining which technique found it and which cells were involved.
+// The chain tries, in order: naked single, hidden single, naked
+// pair/triple, pointing pairs, and box-line reduction - the latter
+// three only eliminate candidates, so after each one that makes
+// progress the chain retries the single-finding rules.
+//
+// When an elimination technique is what unblocks the eventual single,
+// the returned Reason reports that harder technique (not just the
+// single it led to), so a grid that genuinely needed a naked pair to
+// crack is reported as such rather than as a plain single. If nothing
+// in the grid can be justified this way (a human would need to
+// guess), ok is false.
+func HintWithReason(g Grid) (move Move, reason Reason, ok bool) {
+	cand := g.Candidates()
+	var hardest *Reason
+
+	for {
+		if m, r, found := findNakedSingle(g, cand); found {
+			return m, withEnabling(hardest, r), true
+		}
+		if m, r, found := findHiddenSingle(g, cand); found {
+			return m, withEnabling(hardest, r), true
+		}
+
+		if r, changed := applyNakedSubsets(g, &cand); changed {
+			hardest = pickHarder(hardest, r)
+			continue
+		}
+		if r, changed := applyPointingPairs(g, &cand); changed {
+			hardest = pickHarder(hardest, r)
+			continue
+		}
+		if r, changed := applyBoxLineReduction(g, &cand); changed {
+			hardest = pickHarder(hardest, r)
+			continue
+		}
+
+		return Move{}, Reason{}, false
+	}
+}
+
+// pickHarder keeps whichever of best and candidate requires the more
+// advanced technique.
+func pickHarder(best *Reason, candidate Reason) *Reason {
+	if best == nil || candidate.Technique > best.Technique {
+		c := candidate
+		return &c
+	}
+	return best
+}
+
+// withEnabling folds the hardest elimination technique used during
+// this derivation into the final single's Reason, so that a single
+// only reachable after e.g. a naked pair fired is reported as
+// requiring a naked pair, not misleadingly as a plain single.
+func withEnabling(hardest *Reason, single Reason) Reason {
+	if hardest == nil {
+		return single
+	}
+	return Reason{
+		Technique: hardest.Technique,
+		Witnesses: append(append([]CellRef{}, hardest.Witnesses...), single.Witnesses...),
+		Unit:      hardest.Unit,
+		Digit:     single.Digit,
+		Detail:    fmt.Sprintf("%s, which then leaves %s", hardest.Detail, single.Detail),
+	}
+}
+
+func findNakedSingle(g Grid, cand Candidates) (Move, Reason, bool) {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if g[row][col] != 0 {
+				continue
+			}
+			if popCount(cand[row][col]) != 1 {
+				continue
+			}
+			d := singleDigit(cand[row][col])
+			return Move{Row: row, Col: col, Value: d},
+				Reason{
+					Technique: NakedSingle,
+					Witnesses: []CellRef{{Row: row, Col: col}},
+					Digit:     d,
+					Detail:    fmt.Sprintf("R%dC%d has only one remaining candidate", row+1, col+1),
+				}, true
+		}
+	}
+	return Move{}, Reason{}, false
+}
+
+func findHiddenSingle(g Grid, cand Candidates) (Move, Reason, bool) {
+	for _, u := range namedUnits() {
+		for d := Cell(1); d <= 9; d++ {
+			bit := maskBit(d)
+			count := 0
+			var only CellRef
+			placed := false
+			for _, rc := range u.cells {
+				r, c := rc[0], rc[1]
+				if g[r][c] == d {
+					placed = true
+					break
+				}
+				if g[r][c] == 0 && cand[r][c]&bit != 0 {
+					count++
+					only = CellRef{Row: r, Col: c}
+				}
+			}
+			if placed || count != 1 {
+				continue
+			}
+			return Move{Row: only.Row, Col: only.Col, Value: d},
+				Reason{
+					Technique: HiddenSingle,
+					Witnesses: []CellRef{only},
+					Unit:      u.name,
+					Digit:     d,
+					Detail:    fmt.Sprintf("R%dC%d is a hidden single for %d in %s", only.Row+1, only.Col+1, d, u.name),
+				}, true
+		}
+	}
+	return Move{}, Reason{}, false
+}
+
+// applyNakedSubsets looks for naked pairs and triples in every unit:
+// n cells sharing exactly n candidates between them, whose candidates
+// can therefore be eliminated from the rest of the unit. It mutates
+// cand in place and returns the Reason for the first elimination
+// made, if any.
+func applyNakedSubsets(g Grid, cand *Candidates) (Reason, bool) {
+	for _, u := range namedUnits() {
+		var open []CellRef
+		for _, rc := range u.cells {
+			r, c := rc[0], rc[1]
+			if g[r][c] == 0 {
+				open = append(open, CellRef{Row: r, Col: c})
+			}
+		}
+
+		for size := 2; size <= 3; size++ {
+			if r, changed := combineAndEliminate(cand, open, size, u.name); changed {
+				return r, true
+			}
+		}
+	}
+	return Reason{}, false
+}
+
+// combineAndEliminate tries every combination of size cells from
+// open whose candidates fit within size digits, and if found,
+// eliminates those digits from the rest of open.
+func combineAndEliminate(cand *Candidates, open []CellRef, size int, unitName string) (Reason, bool) {
+	n := len(open)
+	if n <= size {
+		return Reason{}, false
+	}
+
+	var combo []int
+	var result Reason
+	found := false
+
+	var recurse func(start int) bool
+	recurse = func(start int) bool {
+		if len(combo) == size {
+			var union uint16
+			witnesses := make([]CellRef, 0, size)
+			for _, idx := range combo {
+				c := open[idx]
+				if popCount(cand[c.Row][c.Col]) > size {
+					return false
+				}
+				union |= cand[c.Row][c.Col]
+				witnesses = append(witnesses, c)
+			}
+			if popCount(union) != size {
+				return false
+			}
+
+			inCombo := make(map[int]bool, size)
+			for _, idx := range combo {
+				inCombo[idx] = true
+			}
+
+			changed := false
+			for i, c := range open {
+				if inCombo[i] {
+					continue
+				}
+				if cand[c.Row][c.Col]&union == 0 {
+					continue
+				}
+				cand[c.Row][c.Col] &^= union
+				changed = true
+			}
+			if !changed {
+				return false
+			}
+
+			technique := NakedPair
+			if size == 3 {
+				technique = NakedTriple
+			}
+			result = Reason{
+				Technique: technique,
+				Witnesses: witnesses,
+				Unit:      unitName,
+				Detail: fmt.Sprintf("%s form a naked %s in %s, eliminating their candidates from the rest of the unit",
+					cellList(witnesses), subsetName(size), unitName),
+			}
+			found = true
+			return true
+		}
+
+		for i := start; i < n; i++ {
+			combo = append(combo, i)
+			if recurse(i + 1) {
+				return true
+			}
+			combo = combo[:len(combo)-1]
+		}
+		return false
+	}
+
+	recurse(0)
+	return result, found
+}
+
+func subsetName(size int) string {
+	if size == 2 {
+		return "pair"
+	}
+	return "triple"
+}
+
+func cellList(cells []CellRef) string {
+	parts := make([]string, len(cells))
+	for i, c := range cells {
+		parts[i] = fmt.Sprintf("R%dC%d", c.Row+1, c.Col+1)
+	}
+	return strings.Join(parts, ",")
+}
+
+// applyPointingPairs looks, within each box, for a digit whose
+// remaining candidates are confined to a single row or column; that
+// digit can then be eliminated from the rest of that row/column
+// outside the box.
+func applyPointingPairs(g Grid, cand *Candidates) (Reason, bool) {
+	for boxRow := 0; boxRow < 3; boxRow++ {
+		for boxCol := 0; boxCol < 3; boxCol++ {
+			box := boxRow*3 + boxCol
+			for d := Cell(1); d <= 9; d++ {
+				bit := maskBit(d)
+				var cells []CellRef
+				for r := boxRow * 3; r < boxRow*3+3; r++ {
+					for c := boxCol * 3; c < boxCol*3+3; c++ {
+						if g[r][c] == 0 && cand[r][c]&bit != 0 {
+							cells = append(cells, CellRef{Row: r, Col: c})
+						}
+					}
+				}
+				if len(cells) < 2 {
+					continue
+				}
+
+				sameRow, sameCol := true, true
+				for _, c := range cells {
+					if c.Row != cells[0].Row {
+						sameRow = false
+					}
+					if c.Col != cells[0].Col {
+						sameCol = false
+					}
+				}
+
+				if sameRow && eliminateFromRow(cand, cells[0].Row, boxCol, bit) {
+					return Reason{
+						Technique: PointingPair,
+						Witnesses: cells,
+						Unit:      unitName("box", box),
+						Digit:     d,
+						Detail: fmt.Sprintf("%d is confined to row %d within %s, so it can be eliminated elsewhere in that row",
+							d, cells[0].Row+1, unitName("box", box)),
+					}, true
+				}
+				if sameCol && eliminateFromCol(cand, cells[0].Col, boxRow, bit) {
+					return Reason{
+						Technique: PointingPair,
+						Witnesses: cells,
+						Unit:      unitName("box", box),
+						Digit:     d,
+						Detail: fmt.Sprintf("%d is confined to column %d within %s, so it can be eliminated elsewhere in that column",
+							d, cells[0].Col+1, unitName("box", box)),
+					}, true
+				}
+			}
+		}
+	}
+	return Reason{}, false
+}
+
+func eliminateFromRow(cand *Candidates, row, excludeBoxCol int, bit uint16) bool {
+	changed := false
+	for c := 0; c < 9; c++ {
+		if c/3 == excludeBoxCol {
+			continue
+		}
+		if cand[row][c]&bit != 0 {
+			cand[row][c] &^= bit
+			changed = true
+		}
+	}
+	return changed
+}
+
+func eliminateFromCol(cand *Candidates, col, excludeBoxRow int, bit uint16) bool {
+	changed := false
+	for r := 0; r < 9; r++ {
+		if r/3 == excludeBoxRow {
+			continue
+		}
+		if cand[r][col]&bit != 0 {
+			cand[r][col] &^= bit
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyBoxLineReduction is the converse of applyPointingPairs: within
+// each row or column, if a digit's remaining candidates are confined
+// to a single box, it can be eliminated from the rest of that box.
+func applyBoxLineReduction(g Grid, cand *Candidates) (Reason, bool) {
+	for row := 0; row < 9; row++ {
+		for d := Cell(1); d <= 9; d++ {
+			bit := maskBit(d)
+			boxCol := -1
+			confined := true
+			var cells []CellRef
+			for c := 0; c < 9; c++ {
+				if g[row][c] == 0 && cand[row][c]&bit != 0 {
+					b := c / 3
+					if boxCol == -1 {
+						boxCol = b
+					} else if boxCol != b {
+						confined = false
+					}
+					cells = append(cells, CellRef{Row: row, Col: c})
+				}
+			}
+			if boxCol == -1 || !confined {
+				continue
+			}
+			box := (row/3)*3 + boxCol
+			if eliminateFromBoxExceptRow(cand, row, boxCol, bit) {
+				return Reason{
+					Technique: BoxLineReduction,
+					Witnesses: cells,
+					Unit:      unitName("row", row),
+					Digit:     d,
+					Detail: fmt.Sprintf("%d in %s is confined to %s, so it can be eliminated elsewhere in that box",
+						d, unitName("row", row), unitName("box", box)),
+				}, true
+			}
+		}
+	}
+
+	for col := 0; col < 9; col++ {
+		for d := Cell(1); d <= 9; d++ {
+			bit := maskBit(d)
+			boxRow := -1
+			confined := true
+			var cells []CellRef
+			for r := 0; r < 9; r++ {
+				if g[r][col] == 0 && cand[r][col]&bit != 0 {
+					b := r / 3
+					if boxRow == -1 {
+						boxRow = b
+					} else if boxRow != b {
+						confined = false
+					}
+					cells = append(cells, CellRef{Row: r, Col: col})
+				}
+			}
+			if boxRow == -1 || !confined {
+				continue
+			}
+			box := boxRow*3 + col/3
+			if eliminateFromBoxExceptCol(cand, col, boxRow, bit) {
+				return Reason{
+					Technique: BoxLineReduction,
+					Witnesses: cells,
+					Unit:      unitName("column", col),
+					Digit:     d,
+					Detail: fmt.Sprintf("%d in %s is confined to %s, so it can be eliminated elsewhere in that box",
+						d, unitName("column", col), unitName("box", box)),
+				}, true
+			}
+		}
+	}
+
+	return Reason{}, false
+}
+
+func eliminateFromBoxExceptRow(cand *Candidates, row, boxCol int, bit uint16) bool {
+	changed := false
+	boxRowStart := row / 3 * 3
+	boxColStart := boxCol * 3
+	for r := boxRowStart; r < boxRowStart+3; r++ {
+		if r == row {
+			continue
+		}
+		for c := boxColStart; c < boxColStart+3; c++ {
+			if cand[r][c]&bit != 0 {
+				cand[r][c] &^= bit
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func eliminateFromBoxExceptCol(cand *Candidates, col, boxRow int, bit uint16) bool {
+	changed := false
+	boxColStart := col / 3 * 3
+	boxRowStart := boxRow * 3
+	for c := boxColStart; c < boxColStart+3; c++ {
+		if c == col {
+			continue
+		}
+		for r := boxRowStart; r < boxRowStart+3; r++ {
+			if cand[r][c]&bit != 0 {
+				cand[r][c] &^= bit
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+type namedUnit struct {
+	name  string
+	cells [9][2]int
+}
+
+// namedUnits attaches a human-readable name (e.g. "row 3", "box 7")
+// to each of allUnits' 27 units, for use in Reason.Detail/Reason.Unit.
+// It relies on allUnits producing its 9 rows, then 9 columns, then 9
+// boxes in that fixed order.
+func namedUnits() []namedUnit {
+	units := allUnits()
+	named := make([]namedUnit, len(units))
+
+	for row := 0; row < 9; row++ {
+		named[row] = namedUnit{name: unitName("row", row), cells: units[row]}
+	}
+	for col := 0; col < 9; col++ {
+		named[9+col] = namedUnit{name: unitName("column", col), cells: units[9+col]}
+	}
+	for box := 0; box < 9; box++ {
+		named[18+box] = namedUnit{name: unitName("box", box), cells: units[18+box]}
+	}
+
+	return named
+}
@@ -0,0 +1,59 @@
+package sudokusolver_test
+
+import (
+	"testing"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHintWithReason_NakedSingle(t *testing.T) {
+	// Every cell is filled except (0,2), which is forced to 4 by its
+	// row, column, and box peers.
+	grid := filled
+	grid[0][2] = 0
+
+	move, reason, ok := sudokusolver.HintWithReason(grid)
+
+	assert.True(t, ok)
+	assert.Equal(t, sudokusolver.Move{Row: 0, Col: 2, Value: 4}, move)
+	assert.Equal(t, sudokusolver.NakedSingle, reason.Technique)
+	assert.Equal(t, []sudokusolver.CellRef{{Row: 0, Col: 2}}, reason.Witnesses)
+}
+
+func TestHintWithReason_Solved(t *testing.T) {
+	_, _, ok := sudokusolver.HintWithReason(filled)
+
+	assert.False(t, ok)
+}
+
+func TestHintWithReason_Partial(t *testing.T) {
+	move, reason, ok := sudokusolver.HintWithReason(partial)
+
+	assert.True(t, ok)
+	assert.Equal(t, filled[move.Row][move.Col], move.Value)
+	assert.NotEmpty(t, reason.Witnesses)
+}
+
+func TestHintWithReason_ReportsEnablingTechnique(t *testing.T) {
+	// A puzzle hard enough to need Generate's difficulty grading
+	// (chunk0-3) reaching Hard exercises a naked pair/triple or
+	// pointing pair before any single becomes available; verify
+	// HintWithReason surfaces that harder technique rather than
+	// silently reporting the single it unblocks as a plain single.
+	grid := sudokusolver.Generate(42, sudokusolver.Hard)
+
+	sawHarderTechnique := false
+	for {
+		move, reason, ok := sudokusolver.HintWithReason(grid)
+		if !ok {
+			break
+		}
+		if reason.Technique > sudokusolver.HiddenSingle {
+			sawHarderTechnique = true
+		}
+		grid = grid.Apply(move)
+	}
+
+	assert.True(t, sawHarderTechnique, "expected a Hard puzzle to need more than naked/hidden singles")
+}
@@ -0,0 +1,347 @@
+package sudokusolver
+
+// ---
+// --- Candidates
+// ---
+
+// fullMask has bits 0-8 set, representing digits 1-9 all being
+// possible.
+const fullMask uint16 = 0x1FF
+
+// maskBit returns the single bit representing the given digit.
+func maskBit(d Cell) uint16 {
+	return 1 << (d - 1)
+}
+
+// popCount returns the number of set bits in mask.
+func popCount(mask uint16) int {
+	count := 0
+	for mask != 0 {
+		mask &= mask - 1
+		count++
+	}
+	return count
+}
+
+// singleDigit returns the digit represented by mask, assuming
+// popCount(mask) == 1. Behaviour is undefined otherwise.
+func singleDigit(mask uint16) Cell {
+	for d := Cell(1); d <= 9; d++ {
+		if mask == maskBit(d) {
+			return d
+		}
+	}
+	return 0
+}
+
+// Candidates holds, for every cell, a bitmask of which digits (1-9)
+// are still possible there. Bit (d-1) of Candidates[row][col] is set
+// if digit d is a candidate for that cell.
+type Candidates [9][9]uint16
+
+// peersOf returns the (up to 20) distinct cells that share a row,
+// column, or box with (row, col), excluding (row, col) itself.
+func peersOf(row, col int) [][2]int {
+	var peers [][2]int
+	seen := map[[2]int]bool{}
+	add := func(r, c int) {
+		if r == row && c == col {
+			return
+		}
+		key := [2]int{r, c}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		peers = append(peers, key)
+	}
+
+	for i := 0; i < 9; i++ {
+		add(row, i)
+		add(i, col)
+	}
+
+	boxRow, boxCol := (row/3)*3, (col/3)*3
+	for r := boxRow; r < boxRow+3; r++ {
+		for c := boxCol; c < boxCol+3; c++ {
+			add(r, c)
+		}
+	}
+
+	return peers
+}
+
+// allUnits returns the 27 units (9 rows, 9 columns, 9 boxes) of a
+// grid, each as a list of its 9 member cells.
+func allUnits() [][9][2]int {
+	var units [][9][2]int
+
+	for row := 0; row < 9; row++ {
+		var unit [9][2]int
+		for col := 0; col < 9; col++ {
+			unit[col] = [2]int{row, col}
+		}
+		units = append(units, unit)
+	}
+
+	for col := 0; col < 9; col++ {
+		var unit [9][2]int
+		for row := 0; row < 9; row++ {
+			unit[row] = [2]int{row, col}
+		}
+		units = append(units, unit)
+	}
+
+	for boxRow := 0; boxRow < 3; boxRow++ {
+		for boxCol := 0; boxCol < 3; boxCol++ {
+			var unit [9][2]int
+			i := 0
+			for r := boxRow * 3; r < boxRow*3+3; r++ {
+				for c := boxCol * 3; c < boxCol*3+3; c++ {
+					unit[i] = [2]int{r, c}
+					i++
+				}
+			}
+			units = append(units, unit)
+		}
+	}
+
+	return units
+}
+
+// Candidates computes the candidate digits for every cell of g, based
+// solely on the digits already placed in peer cells. It does not
+// apply any further propagation.
+func (g Grid) Candidates() Candidates {
+	var cand Candidates
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if g[row][col] != 0 {
+				cand[row][col] = maskBit(g[row][col])
+				continue
+			}
+
+			mask := fullMask
+			for _, p := range peersOf(row, col) {
+				if v := g[p[0]][p[1]]; v != 0 {
+					mask &^= maskBit(v)
+				}
+			}
+			cand[row][col] = mask
+		}
+	}
+	return cand
+}
+
+// isComplete reports whether every cell of g is filled.
+func isComplete(g Grid) bool {
+	for _, rowCells := range g {
+		for _, cell := range rowCells {
+			if cell == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ---
+// --- Constraint propagation solving
+// ---
+
+// Solve via constraint propagation (Norvig-style): eliminate
+// candidates that conflict with assigned peers, assign any cell or
+// unit that is left with only one option, and repeat to a fixpoint.
+// If the grid is not fully solved thereafter, fall back to searching
+// the cell with the fewest remaining candidates.
+//
+// This is typically orders of magnitude faster than Backtrack, since
+// most puzzles are solved entirely by propagation without any search.
+func ConstraintPropagate(grid Grid) (Grid, bool) {
+	return constraintPropagate(grid, nil)
+}
+
+var _ Solver = ConstraintPropagate
+
+// ConstraintPropagateWithConstraints is ConstraintPropagate, extended
+// to also eliminate candidates across each Constraint's Peers and to
+// require every Constraint to hold.
+func ConstraintPropagateWithConstraints(grid Grid, constraints []Constraint) (Grid, bool) {
+	return constraintPropagate(grid, constraints)
+}
+
+// constraintPropagate is the shared implementation behind
+// ConstraintPropagate and ConstraintPropagateWithConstraints;
+// constraints is nil for plain ConstraintPropagate.
+func constraintPropagate(grid Grid, constraints []Constraint) (Grid, bool) {
+	if !grid.Valid() || !gridSatisfies(grid, constraints) {
+		return grid, false
+	}
+
+	assigned := grid
+	cand := grid.Candidates()
+
+	if !propagate(&assigned, &cand, constraints) {
+		return grid, false
+	}
+	if isComplete(assigned) {
+		if !gridSatisfies(assigned, constraints) {
+			return grid, false
+		}
+		return assigned, true
+	}
+
+	return searchCandidates(assigned, cand, constraints)
+}
+
+// constraintPeers gathers the extra peers (beyond the standard
+// row/column/box ones) that constraints say (row, col) may not repeat
+// a digit with.
+func constraintPeers(row, col int, constraints []Constraint) [][2]int {
+	var peers [][2]int
+	for _, c := range constraints {
+		for _, ref := range c.Peers(row, col) {
+			peers = append(peers, [2]int{ref.Row, ref.Col})
+		}
+	}
+	return peers
+}
+
+// assign places digit d at (row, col), recording it in assigned and
+// cand, and eliminates d from the candidates of every unassigned
+// peer (standard, plus any extra constraint peers). It returns false
+// if this collapses any peer's candidate set to empty.
+func assign(assigned *Grid, cand *Candidates, row, col int, d Cell, constraints []Constraint) bool {
+	assigned[row][col] = d
+	cand[row][col] = maskBit(d)
+
+	bit := maskBit(d)
+	peers := append(peersOf(row, col), constraintPeers(row, col, constraints)...)
+	for _, p := range peers {
+		pr, pc := p[0], p[1]
+		if assigned[pr][pc] != 0 {
+			continue
+		}
+		if cand[pr][pc]&bit == 0 {
+			continue
+		}
+		cand[pr][pc] &^= bit
+		if cand[pr][pc] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// propagate applies the "eliminate" and "only-choice" rules to a
+// fixpoint, mutating assigned and cand in place. It returns false if
+// propagation discovers the grid is unsolvable.
+func propagate(assigned *Grid, cand *Candidates, constraints []Constraint) bool {
+	changed := true
+	for changed {
+		changed = false
+
+		// Eliminate: any cell left with a single candidate must be
+		// that digit.
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				if assigned[row][col] != 0 {
+					continue
+				}
+				if popCount(cand[row][col]) != 1 {
+					continue
+				}
+				if !assign(assigned, cand, row, col, singleDigit(cand[row][col]), constraints) {
+					return false
+				}
+				changed = true
+			}
+		}
+
+		// Only-choice: if a digit has only one possible cell left in
+		// a unit, it must go there.
+		for _, unit := range allUnits() {
+			for d := Cell(1); d <= 9; d++ {
+				bit := maskBit(d)
+				count := 0
+				var only [2]int
+				placed := false
+				for _, rc := range unit {
+					r, c := rc[0], rc[1]
+					if assigned[r][c] == d {
+						placed = true
+						break
+					}
+					if assigned[r][c] == 0 && cand[r][c]&bit != 0 {
+						count++
+						only = [2]int{r, c}
+					}
+				}
+				if placed || count != 1 {
+					continue
+				}
+				if !assign(assigned, cand, only[0], only[1], d, constraints) {
+					return false
+				}
+				changed = true
+			}
+		}
+	}
+
+	return true
+}
+
+// minCandidateCell returns the unassigned cell with the fewest
+// remaining candidates (the minimum-remaining-values heuristic).
+func minCandidateCell(assigned Grid, cand Candidates) (row, col int, ok bool) {
+	best := 10
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if assigned[r][c] != 0 {
+				continue
+			}
+			n := popCount(cand[r][c])
+			if n < best {
+				best, row, col, ok = n, r, c, true
+			}
+		}
+	}
+	return row, col, ok
+}
+
+// searchCandidates branches on the cell with the fewest remaining
+// candidates, propagating and recursing on each option.
+func searchCandidates(assigned Grid, cand Candidates, constraints []Constraint) (Grid, bool) {
+	row, col, ok := minCandidateCell(assigned, cand)
+	if !ok {
+		return assigned, true
+	}
+
+	for d := Cell(1); d <= 9; d++ {
+		bit := maskBit(d)
+		if cand[row][col]&bit == 0 {
+			continue
+		}
+
+		branchAssigned := assigned
+		branchCand := cand
+		if !assign(&branchAssigned, &branchCand, row, col, d, constraints) {
+			continue
+		}
+		if !propagate(&branchAssigned, &branchCand, constraints) {
+			continue
+		}
+		if !gridSatisfies(branchAssigned, constraints) {
+			continue
+		}
+		if isComplete(branchAssigned) {
+			return branchAssigned, true
+		}
+		if filled, solved := searchCandidates(branchAssigned, branchCand, constraints); solved {
+			return filled, true
+		}
+	}
+
+	return assigned, false
+}
@@ -0,0 +1,55 @@
+package sudokusolver_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintPropagate_Solvable(t *testing.T) {
+	start := time.Now()
+	actualGrid, actualSolved := sudokusolver.ConstraintPropagate(partial)
+	end := time.Now()
+
+	assert.Equal(t, filled, actualGrid)
+	assert.True(t, actualSolved)
+	fmt.Print("TIME: ", end.Sub(start), "\n")
+}
+
+func TestConstraintPropagate_Unsolvable(t *testing.T) {
+	_, actualSolved := sudokusolver.ConstraintPropagate(unsolvable)
+
+	assert.False(t, actualSolved)
+}
+
+func TestConstraintPropagateWithConstraints_RejectsFailingConstraintEvenWhenPureElimination(t *testing.T) {
+	// partial is solved entirely by propagate's elimination/only-choice
+	// rules, with no need for searchCandidates - so this exercises the
+	// "isComplete right after propagate" path specifically, not just
+	// the search fallback's own gridSatisfies check.
+	badCage := sudokusolver.CageConstraint{Cages: []sudokusolver.Cage{
+		{Cells: []sudokusolver.CellRef{{Row: 0, Col: 2}, {Row: 0, Col: 3}}, Sum: 99},
+	}}
+
+	_, actualSolved := sudokusolver.ConstraintPropagateWithConstraints(partial, []sudokusolver.Constraint{badCage})
+
+	assert.False(t, actualSolved)
+}
+
+func TestGrid_Candidates(t *testing.T) {
+	cand := partial.Candidates()
+
+	// (0,2) is empty; row 0 has 5,3,7; col 2 has 8; box has 5,3,6,9,8.
+	// So only 1,2,4 remain.
+	var expected uint16
+	for _, d := range []sudokusolver.Cell{1, 2, 4} {
+		expected |= 1 << (d - 1)
+	}
+	assert.Equal(t, expected, cand[0][2])
+
+	// A filled cell's only candidate is its own digit.
+	assert.Equal(t, uint16(1<<(5-1)), cand[0][0])
+}
@@ -272,8 +272,15 @@ type Solver func(Grid) (Grid, bool)
 
 // Solve via a traditional backtracking depth-first search.
 func Backtrack(grid Grid) (Grid, bool) {
+	return backtrack(grid, nil)
+}
+
+// backtrack is the shared implementation behind Backtrack and
+// BacktrackWithConstraints (variant.go); constraints is nil for plain
+// Backtrack.
+func backtrack(grid Grid, constraints []Constraint) (Grid, bool) {
 	// If the input grid is not valid, stop.
-	if !grid.Valid() {
+	if !grid.Valid() || !gridSatisfies(grid, constraints) {
 		return grid, false
 	}
 
@@ -292,7 +299,7 @@ func Backtrack(grid Grid) (Grid, bool) {
 				variant[row][col] = i
 
 				// Try solve that variant
-				filled, solved := Backtrack(variant)
+				filled, solved := backtrack(variant, constraints)
 
 				// If solved, then we are done.
 				if solved {
@@ -0,0 +1,246 @@
+package sudokusolver
+
+// ---
+// --- Variant constraints
+// ---
+//
+// IMPORTANT - this file does NOT close out the request that added it
+// (liampulles/sudoku-solver#chunk0-6, "Generalize Grid to arbitrary
+// NxN variants and support Killer/X-Sudoku constraints") in full. That
+// request had two halves:
+//
+//  1. Turn Grid itself into a parameterised Board[N] (box side N, so
+//     Board[2] is 4x4 shidoku, Board[4] is 16x16). NOT DONE. Go does
+//     not support sizing an array type by a type parameter
+//     (`[N*N]Cell` is not a legal array length when N is generic), so
+//     the only faithful implementation moves Grid to a slice-backed
+//     type and rewrites every solver and test that relies on today's
+//     [9]CellGroup array literals - a much larger, separately
+//     reviewable change in its own right, not something to fold
+//     silently into this commit. It needs its own tracked follow-up
+//     request before this module can claim NxN support.
+//  2. A Constraint interface plus 9x9 variant rule sets. DONE - this
+//     is everything else in this file.
+//
+// What follows is only the second half: a Constraint interface that
+// the built-in row/column/box rules could eventually be expressed
+// through, plus the most common 9x9 variant rule sets (X-Sudoku,
+// Windoku, Killer) implemented against it, and constraint-aware
+// variants of all three solvers:
+//
+//   - BacktrackWithConstraints shares Backtrack's recursive core
+//     (sudoku.go), adding a Constraint check at every node.
+//   - ConstraintPropagateWithConstraints (propagate.go) folds each
+//     Constraint's Peers into elimination, so a placed digit is also
+//     struck from its diagonal/window/cage peers, not just its
+//     row/column/box ones.
+//   - DLXWithConstraints (dlx.go) does not attempt to encode
+//     constraints as exact-cover columns - a Cage's sum rule in
+//     particular isn't a cover constraint at all - so it instead
+//     post-filters each exact-cover solution with gridSatisfies before
+//     yielding it. This is correct but, for a constraint that prunes
+//     late (e.g. a tight Cage sum), can mean generating and discarding
+//     more candidate solutions than a column-encoded version would.
+
+// Constraint is an extra rule a Grid must satisfy, on top of the
+// standard row/column/box constraints.
+type Constraint interface {
+	// Check reports whether g currently satisfies the constraint. It
+	// must tolerate partially-filled grids: a 0 cell is simply not
+	// yet constrained.
+	Check(g Grid) bool
+
+	// Peers returns the extra cells that may not repeat a digit with
+	// (row, col) under this constraint, beyond the standard peers
+	// from Grid's row/column/box.
+	Peers(row, col int) []CellRef
+}
+
+// gridSatisfies reports whether g satisfies every constraint.
+func gridSatisfies(g Grid, constraints []Constraint) bool {
+	for _, c := range constraints {
+		if !c.Check(g) {
+			return false
+		}
+	}
+	return true
+}
+
+// BacktrackWithConstraints is Backtrack, extended to also require
+// every given Constraint to hold. It shares its recursive search with
+// Backtrack (sudoku.go).
+func BacktrackWithConstraints(grid Grid, constraints []Constraint) (Grid, bool) {
+	return backtrack(grid, constraints)
+}
+
+// ---
+// --- X-Sudoku
+// ---
+
+// DiagonalConstraint is the X-Sudoku rule: both main diagonals must
+// contain each digit 1-9 at most once.
+type DiagonalConstraint struct{}
+
+var _ Constraint = DiagonalConstraint{}
+
+func (DiagonalConstraint) Check(g Grid) bool {
+	return diagonalValid(g, true) && diagonalValid(g, false)
+}
+
+func diagonalValid(g Grid, topLeftToBottomRight bool) bool {
+	var seen [10]bool
+	for i := 0; i < 9; i++ {
+		var cell Cell
+		if topLeftToBottomRight {
+			cell = g[i][i]
+		} else {
+			cell = g[i][8-i]
+		}
+		if cell == 0 {
+			continue
+		}
+		if seen[cell] {
+			return false
+		}
+		seen[cell] = true
+	}
+	return true
+}
+
+func (DiagonalConstraint) Peers(row, col int) []CellRef {
+	var peers []CellRef
+	if row == col {
+		for i := 0; i < 9; i++ {
+			if i != row {
+				peers = append(peers, CellRef{Row: i, Col: i})
+			}
+		}
+	}
+	if row+col == 8 {
+		for i := 0; i < 9; i++ {
+			if i != row {
+				peers = append(peers, CellRef{Row: i, Col: 8 - i})
+			}
+		}
+	}
+	return peers
+}
+
+// ---
+// --- Windoku
+// ---
+
+// windokuWindows are the top-left corners of Windoku's four extra
+// 3x3 regions.
+var windokuWindows = [4][2]int{{1, 1}, {1, 5}, {5, 1}, {5, 5}}
+
+// WindowConstraint is the Windoku (hyper-sudoku) rule: four extra
+// 3x3 windows, offset from the standard boxes, must each contain each
+// digit 1-9 at most once.
+type WindowConstraint struct{}
+
+var _ Constraint = WindowConstraint{}
+
+func (WindowConstraint) Check(g Grid) bool {
+	for _, w := range windokuWindows {
+		var seen [10]bool
+		for r := w[0]; r < w[0]+3; r++ {
+			for c := w[1]; c < w[1]+3; c++ {
+				cell := g[r][c]
+				if cell == 0 {
+					continue
+				}
+				if seen[cell] {
+					return false
+				}
+				seen[cell] = true
+			}
+		}
+	}
+	return true
+}
+
+func (WindowConstraint) Peers(row, col int) []CellRef {
+	var peers []CellRef
+	for _, w := range windokuWindows {
+		if row < w[0] || row >= w[0]+3 || col < w[1] || col >= w[1]+3 {
+			continue
+		}
+		for r := w[0]; r < w[0]+3; r++ {
+			for c := w[1]; c < w[1]+3; c++ {
+				if r == row && c == col {
+					continue
+				}
+				peers = append(peers, CellRef{Row: r, Col: c})
+			}
+		}
+	}
+	return peers
+}
+
+// ---
+// --- Killer Sudoku
+// ---
+
+// Cage is a Killer Sudoku cage: a set of cells whose digits must be
+// distinct and sum to Sum.
+type Cage struct {
+	Cells []CellRef
+	Sum   int
+}
+
+// CageConstraint is the Killer Sudoku rule: every Cage's cells must
+// be distinct and sum to its target.
+type CageConstraint struct {
+	Cages []Cage
+}
+
+var _ Constraint = CageConstraint{}
+
+func (k CageConstraint) Check(g Grid) bool {
+	for _, cage := range k.Cages {
+		seen := map[Cell]bool{}
+		sum := 0
+		complete := true
+
+		for _, c := range cage.Cells {
+			v := g[c.Row][c.Col]
+			if v == 0 {
+				complete = false
+				continue
+			}
+			if seen[v] {
+				return false
+			}
+			seen[v] = true
+			sum += int(v)
+		}
+
+		if sum > cage.Sum {
+			return false
+		}
+		if complete && sum != cage.Sum {
+			return false
+		}
+	}
+	return true
+}
+
+func (k CageConstraint) Peers(row, col int) []CellRef {
+	for _, cage := range k.Cages {
+		for _, c := range cage.Cells {
+			if c.Row != row || c.Col != col {
+				continue
+			}
+
+			var peers []CellRef
+			for _, other := range cage.Cells {
+				if other != c {
+					peers = append(peers, other)
+				}
+			}
+			return peers
+		}
+	}
+	return nil
+}
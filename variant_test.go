@@ -0,0 +1,89 @@
+package sudokusolver_test
+
+import (
+	"testing"
+
+	sudokusolver "github.com/liampulles/sudoku-solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagonalConstraint_Check(t *testing.T) {
+	var g sudokusolver.Grid
+	for i := 0; i < 9; i++ {
+		g[i][i] = sudokusolver.Cell(i + 1)
+	}
+	assert.True(t, sudokusolver.DiagonalConstraint{}.Check(g))
+
+	g[8][8] = g[0][0] // duplicate on the main diagonal
+	assert.False(t, sudokusolver.DiagonalConstraint{}.Check(g))
+}
+
+func TestWindowConstraint_Check(t *testing.T) {
+	var g sudokusolver.Grid
+	g[1][1] = 5
+	assert.True(t, sudokusolver.WindowConstraint{}.Check(g))
+
+	g[2][2] = 5 // duplicate within the top-left window
+	assert.False(t, sudokusolver.WindowConstraint{}.Check(g))
+}
+
+func TestCageConstraint_Check(t *testing.T) {
+	cage := sudokusolver.Cage{
+		Cells: []sudokusolver.CellRef{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+		Sum:   8,
+	}
+	constraint := sudokusolver.CageConstraint{Cages: []sudokusolver.Cage{cage}}
+
+	assert.True(t, constraint.Check(filled)) // 5 + 3 == 8
+
+	wrongSum := sudokusolver.Cage{
+		Cells: []sudokusolver.CellRef{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+		Sum:   9,
+	}
+	assert.False(t, sudokusolver.CageConstraint{Cages: []sudokusolver.Cage{wrongSum}}.Check(filled))
+}
+
+func TestBacktrackWithConstraints_RespectsDiagonal(t *testing.T) {
+	// The classic puzzle's unique solution repeats digits on its main
+	// diagonal, so adding the X-Sudoku constraint makes it unsolvable.
+	_, solved := sudokusolver.BacktrackWithConstraints(partial, []sudokusolver.Constraint{
+		sudokusolver.DiagonalConstraint{},
+	})
+
+	assert.False(t, solved)
+
+	// With no extra constraints, it is still solvable as normal.
+	_, solvedPlain := sudokusolver.BacktrackWithConstraints(partial, nil)
+	assert.True(t, solvedPlain)
+}
+
+func TestConstraintPropagateWithConstraints_RespectsDiagonal(t *testing.T) {
+	_, solved := sudokusolver.ConstraintPropagateWithConstraints(partial, []sudokusolver.Constraint{
+		sudokusolver.DiagonalConstraint{},
+	})
+
+	assert.False(t, solved)
+
+	_, solvedPlain := sudokusolver.ConstraintPropagateWithConstraints(partial, nil)
+	assert.True(t, solvedPlain)
+}
+
+func TestDLXWithConstraints_RespectsDiagonal(t *testing.T) {
+	_, solved := sudokusolver.DLXWithConstraints(partial, []sudokusolver.Constraint{
+		sudokusolver.DiagonalConstraint{},
+	})
+
+	assert.False(t, solved)
+
+	_, solvedPlain := sudokusolver.DLXWithConstraints(partial, nil)
+	assert.True(t, solvedPlain)
+}
+
+func TestDiagonalConstraint_Peers(t *testing.T) {
+	peers := sudokusolver.DiagonalConstraint{}.Peers(4, 4)
+
+	// (4,4) sits on both diagonals, so it has peers from each, minus
+	// itself.
+	assert.Len(t, peers, 16)
+	assert.NotContains(t, peers, sudokusolver.CellRef{Row: 4, Col: 4})
+}